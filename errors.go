@@ -0,0 +1,22 @@
+// Copyright (c) 2021 Leonid Kneller. All rights reserved.
+// Licensed under the MIT license.
+// See the LICENSE file for full license information.
+
+package kgeo
+
+import "errors"
+
+// Errors returned by the `...E` variants of the package's constructors and
+// solvers, in place of the panics raised by their unchecked counterparts.
+var (
+	ErrEquatorialRadiusOutOfRange = errors.New("kgeo: invalid argument `a`")
+	ErrFlatteningOutOfRange       = errors.New("kgeo: invalid argument `f`")
+	ErrLatOutOfRange              = errors.New("kgeo: invalid argument `lat`")
+	ErrLonOutOfRange              = errors.New("kgeo: invalid argument `lon`")
+	ErrAziOutOfRange              = errors.New("kgeo: invalid argument `azi`")
+	ErrDistanceOutOfRange         = errors.New("kgeo: invalid argument `s12`")
+	// ErrPoleDegenerate is returned when a starting or target latitude is
+	// exactly ±90°: the azimuth at a pole is not well defined, so the caller
+	// must resolve the ambiguity itself rather than have it silently clamped.
+	ErrPoleDegenerate = errors.New("kgeo: latitude of exactly ±90 degrees is degenerate")
+)
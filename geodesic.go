@@ -22,20 +22,45 @@ type Geodesic struct {
 }
 
 // Solution -- represents a solution of direct/inverse geodesic problems.
+//
+// `M12`, `M21`, `ReducedLength`, and `Arc` are only populated when requested
+// via a `Caps` mask passed to `Direct`/`Inverse`/`Line`; otherwise they are zero.
 type Solution struct {
 	Lat1, Lon1, Azi1 float64
 	Lat2, Lon2, Azi2 float64
 	S12              float64
+	M12, M21         float64
+	ReducedLength    float64
+	Arc              float64
 }
 
-// NewGeodesic -- returns a geodesic solver for the spheroid
-// defined by `a` (equatorial axis) and `f` (flattening).
-func NewGeodesic(a, f float64) Geodesic {
+// Caps -- a bitmask of optional outputs that `Direct`, `Inverse`, and `Line`
+// can be asked to compute in addition to the basic `Solution` fields.
+type Caps uint
+
+const (
+	// CapScale requests the geodesic scales M12, M21.
+	CapScale Caps = 1 << iota
+	// CapReducedLength requests the reduced length m12.
+	CapReducedLength
+	// CapArc requests the arc length a12 (in degrees, on the auxiliary sphere).
+	CapArc
+)
+
+// has -- reports whether `caps` requests the outputs in `c`.
+func (caps Caps) has(c Caps) bool {
+	return caps&c == c
+}
+
+// NewGeodesicE -- like `NewGeodesic`, but reports an invalid `a` or `f` by
+// returning an error (`ErrEquatorialRadiusOutOfRange`, `ErrFlatteningOutOfRange`)
+// instead of panicking.
+func NewGeodesicE(a, f float64) (Geodesic, error) {
 	if !(1 <= a && a <= 1e10) {
-		panic("kgeo.NewGeodesic: invalid argument `a`")
+		return Geodesic{}, ErrEquatorialRadiusOutOfRange
 	}
 	if !(0 <= f && f <= 1.0/150) {
-		panic("kgeo.NewGeodesic: invalid argument `f`")
+		return Geodesic{}, ErrFlatteningOutOfRange
 	}
 	if f <= 1.0/(1<<26) {
 		f = 0
@@ -43,6 +68,16 @@ func NewGeodesic(a, f float64) Geodesic {
 	g := Geodesic{a: a, b: a * (1 - f), f: f, n: f / (2 - f), e2: f * (2 - f), ep2: f * (2 - f) / ((1 - f) * (1 - f))}
 	g.cA3 = newCoeffA3(g.n)
 	g.cC3 = newCoeffC3(g.n)
+	return g, nil
+}
+
+// NewGeodesic -- returns a geodesic solver for the spheroid
+// defined by `a` (equatorial axis) and `f` (flattening).
+func NewGeodesic(a, f float64) Geodesic {
+	g, err := NewGeodesicE(a, f)
+	if err != nil {
+		panic("kgeo.NewGeodesic: " + err.Error())
+	}
 	return g
 }
 
@@ -56,33 +91,95 @@ func (g Geodesic) F() float64 {
 	return g.f
 }
 
+// DirectE -- like `Direct`, but reports an invalid argument or a pole-degenerate
+// `lat1` by returning an error (`ErrLatOutOfRange`, `ErrLonOutOfRange`,
+// `ErrAziOutOfRange`, `ErrDistanceOutOfRange`, `ErrPoleDegenerate`) instead of
+// panicking or silently clamping.
+func (g Geodesic) DirectE(lat1, lon1, azi1, s12 float64, caps Caps) (Solution, error) {
+	if !(0 <= s12 && s12 <= 1e11) {
+		return Solution{}, ErrDistanceOutOfRange
+	}
+	b, f, sinα0, cosα0, σ1, ε, A1, C1, err := g.directStartE(lat1, lon1, azi1)
+	if err != nil {
+		return Solution{}, err
+	}
+	I1σ1 := A1 * (σ1 + sumSin(σ1, C1))
+	s1 := b * I1σ1
+	s2 := s1 + s12
+	τ2 := s2 / (b * A1)
+	C1p := seriesC1p(ε)
+	σ2 := τ2 + sumSin(τ2, C1p)
+	sol := g.directTail(lat1, lon1, azi1, f, sinα0, cosα0, σ1, σ2, ε, A1, C1, caps)
+	sol.S12 = nnz(s12)
+	return sol, nil
+}
+
 // Direct -- solves the direct problem: given the source point defined by `lat1` and `lon1`,
 // the azimuth `azi1`, and the geodesic length `s12`, find the target point and the azimuth
-// at that point.
-func (g Geodesic) Direct(lat1, lon1, azi1, s12 float64) Solution {
+// at that point. `caps` selects which of the optional `Solution` outputs (geodesic scales,
+// reduced length, arc length) are also computed; pass 0 to skip all of them.
+func (g Geodesic) Direct(lat1, lon1, azi1, s12 float64, caps Caps) Solution {
+	sol, err := g.DirectE(lat1, lon1, azi1, s12, caps)
+	if err != nil {
+		panic("kgeo.Geodesic.Direct: " + err.Error())
+	}
+	return sol
+}
+
+// ArcDirectE -- the error-returning counterpart of `ArcDirect`; see `DirectE`.
+func (g Geodesic) ArcDirectE(lat1, lon1, azi1, a12 float64, caps Caps) (Solution, error) {
+	b, f, sinα0, cosα0, σ1, ε, A1, C1, err := g.directStartE(lat1, lon1, azi1)
+	if err != nil {
+		return Solution{}, err
+	}
+	σ2 := σ1 + a12*(math.Pi/180)
+	sol := g.directTail(lat1, lon1, azi1, f, sinα0, cosα0, σ1, σ2, ε, A1, C1, caps)
+	I1σ2 := A1 * (σ2 + sumSin(σ2, C1))
+	I1σ1 := A1 * (σ1 + sumSin(σ1, C1))
+	sol.S12 = nnz(b * (I1σ2 - I1σ1))
+	sol.Arc = nnz(a12)
+	return sol, nil
+}
+
+// ArcDirect -- solves the direct problem like `Direct`, but the second point is
+// given by the arc length `a12` (in degrees, on the auxiliary sphere) rather
+// than the geodesic length. This skips the τ→σ inversion that `Direct` performs,
+// so it is the cheaper and numerically preferred way to work in arc-length units
+// (e.g. sampling `n` equally spaced points along a geodesic). The returned
+// `Solution` always carries `a12` back in `Arc`, regardless of `caps`.
+func (g Geodesic) ArcDirect(lat1, lon1, azi1, a12 float64, caps Caps) Solution {
+	sol, err := g.ArcDirectE(lat1, lon1, azi1, a12, caps)
+	if err != nil {
+		panic("kgeo.Geodesic.ArcDirect: " + err.Error())
+	}
+	return sol
+}
+
+// directStartE -- validates `lat1`, `lon1`, `azi1` and solves triangle NEA,
+// returning the quantities shared by `DirectE` and `ArcDirectE` before they
+// diverge on how σ2 is determined. A `lat1` of exactly ±90° is reported as
+// `ErrPoleDegenerate` rather than silently clamped, since the azimuth at a
+// pole is not well defined.
+func (g Geodesic) directStartE(lat1, lon1, azi1 float64) (b, f, sinα0, cosα0, σ1, ε float64, A1 float64, C1 [8]float64, err error) {
 	if !(-90 <= lat1 && lat1 <= +90) {
-		panic("kgeo.Geodesic.Direct: invalid argument `lat1`")
+		err = ErrLatOutOfRange
+		return
 	}
 	if !(-180 <= lon1 && lon1 <= +180) {
-		panic("kgeo.Geodesic.Direct: invalid argument `lon1`")
+		err = ErrLonOutOfRange
+		return
 	}
 	if !(-180 <= azi1 && azi1 <= +180) {
-		panic("kgeo.Geodesic.Direct: invalid argument `azi1`")
+		err = ErrAziOutOfRange
+		return
 	}
-	if !(0 <= s12 && s12 <= 1e11) {
-		panic("kgeo.Geodesic.Direct: invalid argument `s12`")
-	}
-	//
-	{
-		// adjust near-polar latitudes
-		const ε = 1.0 / (1 << 38)
-		if math.Abs(lat1) > 90*(1-ε) {
-			lat1 = math.Copysign(90*(1-ε), lat1)
-		}
+	if math.Abs(lat1) == 90 {
+		err = ErrPoleDegenerate
+		return
 	}
 	//
-	b := g.b
-	f := g.f
+	b = g.b
+	f = g.f
 	ep2 := g.ep2
 	//
 	φ1 := lat1 * (math.Pi / 180)
@@ -93,23 +190,26 @@ func (g Geodesic) Direct(lat1, lon1, azi1, s12 float64) Solution {
 	β1 := math.Atan2((1-f)*sinφ1, cosφ1)
 	sinβ1, cosβ1 := math.Sincos(β1)
 	α0 := math.Atan2(sinα1*cosβ1, math.Hypot(cosα1, sinα1*sinβ1))
-	sinα0, cosα0 := math.Sincos(α0)
-	σ1 := math.Atan2(sinβ1, cosα1*cosβ1)
+	sinα0, cosα0 = math.Sincos(α0)
+	σ1 = math.Atan2(sinβ1, cosα1*cosβ1)
+	//
+	k2 := ep2 * cosα0 * cosα0
+	tt := math.Sqrt(1 + k2)
+	ε = (tt - 1) / (tt + 1)
+	A1 = seriesA1(ε)
+	C1 = seriesC1(ε)
+	return
+}
+
+// directTail -- common tail of `Direct` and `ArcDirect`, given σ2 and the
+// triangle-NEA quantities computed by `directStart`. `S12` is left zero; the
+// caller fills it in, since `Direct` and `ArcDirect` derive it differently.
+func (g Geodesic) directTail(lat1, lon1, azi1, f, sinα0, cosα0, σ1, σ2, ε float64, A1 float64, C1 [8]float64, caps Caps) Solution {
+	b := g.b
+	ep2 := g.ep2
+	k2 := ep2 * cosα0 * cosα0
 	sinσ1, cosσ1 := math.Sincos(σ1)
 	ω1 := math.Atan2(sinα0*sinσ1, cosσ1)
-	// determine σ2
-	var tt float64
-	k2 := ep2 * cosα0 * cosα0
-	tt = math.Sqrt(1 + k2)
-	ε := (tt - 1) / (tt + 1)
-	A1 := seriesA1(ε)
-	C1 := seriesC1(ε)
-	I1σ1 := A1 * (σ1 + sumSin(σ1, C1))
-	s1 := b * I1σ1
-	s2 := s1 + s12
-	τ2 := s2 / (b * A1)
-	C1p := seriesC1p(ε)
-	σ2 := τ2 + sumSin(τ2, C1p)
 	sinσ2, cosσ2 := math.Sincos(σ2)
 	// solve triangle NEB
 	α2 := math.Atan2(sinα0, cosα0*cosσ2)
@@ -136,33 +236,73 @@ func (g Geodesic) Direct(lat1, lon1, azi1, s12 float64) Solution {
 	}
 	azi2 := α2 * (180 / math.Pi)
 	//
-	return Solution{Lat1: nnz(lat1), Lon1: nnz(lon1), Azi1: nnz(azi1), Lat2: nnz(lat2), Lon2: nnz(lon2), Azi2: nnz(azi2), S12: nnz(s12)}
+	sol := Solution{Lat1: nnz(lat1), Lon1: nnz(lon1), Azi1: nnz(azi1), Lat2: nnz(lat2), Lon2: nnz(lon2), Azi2: nnz(azi2)}
+	if caps.has(CapArc) {
+		sol.Arc = nnz((σ2 - σ1) * (180 / math.Pi))
+	}
+	if caps.has(CapScale) || caps.has(CapReducedLength) {
+		A2 := seriesA2(ε)
+		C2 := seriesC2(ε)
+		m12, M12, M21 := lengths(b, k2, σ1, sinσ1, cosσ1, σ2, sinσ2, cosσ2, A1, C1, A2, C2)
+		if caps.has(CapReducedLength) {
+			sol.ReducedLength = nnz(m12)
+		}
+		if caps.has(CapScale) {
+			sol.M12, sol.M21 = nnz(M12), nnz(M21)
+		}
+	}
+	return sol
 }
 
-func (g Geodesic) hybrid(sinβ1, cosβ1, sinβ2, cosβ2, sinα1, cosα1 float64) (float64, float64) {
+// hybrid -- solves the two auxiliary-sphere triangles NEA, NEB for a trial
+// azimuth `α1` at the (already reduced) endpoints β1, β2, and returns the
+// target azimuth `α2`, the arc lengths `σ1`, `σ2`, the longitude difference
+// `λ12`, the distance `s12`, the reduced length `m12`, the geodesic scales
+// `M12`, `M21`, and `dn2` = √(1+k²cos²σ2). This is the per-trial evaluation
+// that `Inverse` drives with Newton's method.
+func (g Geodesic) hybrid(sinβ1, cosβ1, sinβ2, cosβ2, sinα1, cosα1 float64) (α2, σ1, σ2, λ12, s12, m12, M12, M21, dn2 float64) {
+	f := g.f
 	b := g.b
 	ep2 := g.ep2
 	// solve triangle NEA
 	α0 := math.Atan2(sinα1*cosβ1, math.Hypot(cosα1, sinα1*sinβ1))
 	sinα0, cosα0 := math.Sincos(α0)
-	σ1 := math.Atan2(sinβ1, cosα1*cosβ1)
+	σ1 = math.Atan2(sinβ1, cosα1*cosβ1)
+	sinσ1, cosσ1 := math.Sincos(σ1)
+	ω1 := math.Atan2(sinα0*sinσ1, cosσ1)
 	// solve triangle NEB
-	α2 := math.Atan2(sinα0, math.Sqrt(sq(cosα1*cosβ1)+(cosβ2-cosβ1)*(cosβ2+cosβ1)))
-	cosα2 := math.Cos(α2)
-	σ2 := math.Atan2(sinβ2, cosα2*cosβ2)
-	// determine s12 and λ12
+	α2x := math.Atan2(sinα0, math.Sqrt(sq(cosα1*cosβ1)+(cosβ2-cosβ1)*(cosβ2+cosβ1)))
+	cosα2x := math.Cos(α2x)
+	σ2 = math.Atan2(sinβ2, cosα2x*cosβ2)
+	sinσ2, cosσ2 := math.Sincos(σ2)
+	ω2 := math.Atan2(sinα0*sinσ2, cosσ2)
+	// determine s12
 	k2 := ep2 * cosα0 * cosα0
 	tt := math.Sqrt(1 + k2)
 	ε := (tt - 1) / (tt + 1)
 	A1 := seriesA1(ε)
 	C1 := seriesC1(ε)
 	I1σ1 := A1 * (σ1 + sumSin(σ1, C1))
-	s1 := b * I1σ1
 	I1σ2 := A1 * (σ2 + sumSin(σ2, C1))
+	s1 := b * I1σ1
 	s2 := b * I1σ2
-	s12 := s2 - s1
+	// determine λ12
+	A3 := g.cA3.seriesA3(ε)
+	C3 := g.cC3.seriesC3(ε)
+	I3σ1 := A3 * (σ1 + sumSin(σ1, C3))
+	I3σ2 := A3 * (σ2 + sumSin(σ2, C3))
+	λ1 := ω1 - f*sinα0*I3σ1
+	λ2 := ω2 - f*sinα0*I3σ2
+	// determine m12, M12, M21
+	A2 := seriesA2(ε)
+	C2 := seriesC2(ε)
+	m12, M12, M21 = lengths(b, k2, σ1, sinσ1, cosσ1, σ2, sinσ2, cosσ2, A1, C1, A2, C2)
+	dn2 = math.Sqrt(1 + k2*cosσ2*cosσ2)
 	//
-	return α2, s12
+	α2 = α2x
+	λ12 = λ2 - λ1
+	s12 = s2 - s1
+	return
 }
 
 // sq -- square
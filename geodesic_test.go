@@ -0,0 +1,70 @@
+// Copyright (c) 2021 Leonid Kneller. All rights reserved.
+// Licensed under the MIT license.
+// See the LICENSE file for full license information.
+
+package kgeo
+
+import (
+	"math"
+	"testing"
+)
+
+// TestNewGeodesicEValidatesArguments checks that out-of-range `a`/`f` are
+// reported instead of silently clamped.
+func TestNewGeodesicEValidatesArguments(t *testing.T) {
+	if _, err := NewGeodesicE(0, 1.0/298.257223563); err != ErrEquatorialRadiusOutOfRange {
+		t.Errorf("error = %v, want ErrEquatorialRadiusOutOfRange", err)
+	}
+	if _, err := NewGeodesicE(6378137.0, -1); err != ErrFlatteningOutOfRange {
+		t.Errorf("error = %v, want ErrFlatteningOutOfRange", err)
+	}
+	if _, err := NewGeodesicE(6378137.0, 1.0/298.257223563); err != nil {
+		t.Errorf("error = %v, want nil", err)
+	}
+}
+
+// TestDirectWashingtonLondon is the textbook Washington DC -> London pair,
+// driven forward: the same known azimuth/distance from
+// TestInverseWashingtonLondon must land back on London.
+func TestDirectWashingtonLondon(t *testing.T) {
+	g := NewGeodesic(6378137.0, 1.0/298.257223563)
+	sol := g.Direct(38.9, -77.0, 49.389, 5913129.2, 0)
+	if math.Abs(sol.Lat2-51.5) > 1e-3 {
+		t.Errorf("Lat2 = %v, want approximately 51.5", sol.Lat2)
+	}
+	if math.Abs(sol.Lon2-(-0.1)) > 1e-3 {
+		t.Errorf("Lon2 = %v, want approximately -0.1", sol.Lon2)
+	}
+}
+
+// TestArcDirectMatchesDirect checks that ArcDirect and Direct agree when
+// ArcDirect's resulting S12 is fed back into Direct.
+func TestArcDirectMatchesDirect(t *testing.T) {
+	g := NewGeodesic(6378137.0, 1.0/298.257223563)
+	arc := g.ArcDirect(38.9, -77.0, 49.389, 10, CapArc)
+	if math.Abs(arc.Arc-10) > 1e-9 {
+		t.Errorf("Arc = %v, want 10", arc.Arc)
+	}
+	dir := g.Direct(38.9, -77.0, 49.389, arc.S12, 0)
+	if math.Abs(arc.Lat2-dir.Lat2) > 1e-9 || math.Abs(arc.Lon2-dir.Lon2) > 1e-9 {
+		t.Errorf("ArcDirect = (%v,%v), want (%v,%v)", arc.Lat2, arc.Lon2, dir.Lat2, dir.Lon2)
+	}
+}
+
+// TestDirectEValidatesArguments checks that DirectE reports invalid latitude,
+// azimuth, and distance arguments instead of panicking.
+func TestDirectEValidatesArguments(t *testing.T) {
+	g := NewGeodesic(6378137.0, 1.0/298.257223563)
+	if _, err := g.DirectE(91, 0, 0, 0, 0); err != ErrLatOutOfRange {
+		t.Errorf("error = %v, want ErrLatOutOfRange", err)
+	}
+	if _, err := g.DirectE(0, 0, 181, 0, 0); err != ErrAziOutOfRange {
+		t.Errorf("error = %v, want ErrAziOutOfRange", err)
+	}
+	if _, err := g.DirectE(0, 0, 0, -1, 0); err != ErrDistanceOutOfRange {
+		t.Errorf("error = %v, want ErrDistanceOutOfRange", err)
+	}
+	if _, err := g.DirectE(90, 0, 0, 0, 0); err != ErrPoleDegenerate {
+		t.Errorf("error = %v, want ErrPoleDegenerate", err)
+	}
+}
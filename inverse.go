@@ -0,0 +1,282 @@
+// Copyright (c) 2021 Leonid Kneller. All rights reserved.
+// Licensed under the MIT license.
+// See the LICENSE file for full license information.
+
+package kgeo
+
+import (
+	"math"
+)
+
+// Newton iteration limits for `Inverse`: `maxit1` bounds the Newton phase,
+// `maxit2` additionally allows for bisection fallback steps.
+const (
+	maxit1 = 20
+	digits = 53
+	maxit2 = maxit1 + digits + 10
+)
+
+// InverseE -- like `Inverse`, but reports an invalid argument or a
+// pole-degenerate `lat1`/`lat2` by returning an error (`ErrLatOutOfRange`,
+// `ErrLonOutOfRange`, `ErrPoleDegenerate`) instead of panicking or silently
+// clamping.
+func (g Geodesic) InverseE(lat1, lon1, lat2, lon2 float64, caps Caps) (Solution, error) {
+	if !(-90 <= lat1 && lat1 <= +90) {
+		return Solution{}, ErrLatOutOfRange
+	}
+	if !(-180 <= lon1 && lon1 <= +180) {
+		return Solution{}, ErrLonOutOfRange
+	}
+	if !(-90 <= lat2 && lat2 <= +90) {
+		return Solution{}, ErrLatOutOfRange
+	}
+	if !(-180 <= lon2 && lon2 <= +180) {
+		return Solution{}, ErrLonOutOfRange
+	}
+	if math.Abs(lat1) == 90 || math.Abs(lat2) == 90 {
+		// the azimuth at a pole is not well defined; let the caller resolve it
+		return Solution{}, ErrPoleDegenerate
+	}
+	//
+	f := g.f
+	//
+	φ1 := lat1 * (math.Pi / 180)
+	φ2 := lat2 * (math.Pi / 180)
+	sinφ1, cosφ1 := math.Sincos(φ1)
+	sinφ2, cosφ2 := math.Sincos(φ2)
+	β1 := math.Atan2((1-f)*sinφ1, cosφ1)
+	β2 := math.Atan2((1-f)*sinφ2, cosφ2)
+	//
+	lon12 := lon2 - lon1
+	if lon12 < -180 {
+		lon12 += 360
+	}
+	if lon12 > +180 {
+		lon12 -= 360
+	}
+	λ12 := lon12 * (math.Pi / 180)
+	//
+	// reduce to β1<=0, |β1|>=|β2|
+	swap := math.Abs(β1) < math.Abs(β2)
+	if swap {
+		β1, β2 = β2, β1
+	}
+	negate := β1 > 0
+	if negate {
+		β1, β2 = -β1, -β2
+	}
+	// swapping which point is "1" reverses the sense of travel, which has the
+	// same effect on α1/α2 as negating β1,β2 does; flip only when exactly one
+	// of the two reductions applied, since two reversals cancel out
+	flip := negate != swap
+	sinβ1, cosβ1 := math.Sincos(β1)
+	sinβ2, cosβ2 := math.Sincos(β2)
+	//
+	var α1, α2, σ1, σ2, s12, m12, M12, M21 float64
+	//
+	switch {
+	case sinβ1 == 0 && sinβ2 == 0:
+		// equatorial shortcut: the geodesic runs along the equator, heading
+		// east or west according to the sign of λ12. On the equator α0==±π/2,
+		// so hybrid's usual σ2 := atan2(sinβ2, ...) recovery is indeterminate
+		// (sinβ stays 0 for every σ); solve for σ2 from λ12 directly instead,
+		// by fixed-point iteration on λ(σ2) = σ2 - f·A3·(σ2+ΣC3) = |λ12|,
+		// which converges quickly since f is small
+		sinα0 := math.Copysign(1, λ12)
+		α1 = (math.Pi / 2) * sinα0
+		const ε = 0.0 // k2 = ep2*cosα0² == 0 on the equator
+		A3 := g.cA3.seriesA3(ε)
+		C3 := g.cC3.seriesC3(ε)
+		target := math.Abs(λ12)
+		σ2x := target
+		for i := 0; i < 10; i++ {
+			σ2x = target + f*A3*(σ2x+sumSin(σ2x, C3))
+		}
+		sinσ2, cosσ2 := math.Sincos(σ2x)
+		A1 := seriesA1(ε)
+		C1 := seriesC1(ε)
+		A2 := seriesA2(ε)
+		C2 := seriesC2(ε)
+		m12x, M12x, M21x := lengths(g.b, 0, 0, 0, 1, σ2x, sinσ2, cosσ2, A1, C1, A2, C2)
+		σ1, σ2 = 0, σ2x
+		s12 = g.b * A1 * (σ2x + sumSin(σ2x, C1))
+		α2, m12, M12, M21 = α1, m12x, M12x, M21x
+	case λ12 == 0 || math.Abs(λ12) == math.Pi:
+		// meridional shortcut: the geodesic runs along a meridian. The
+		// equatorial case is checked first above: for two equatorial points
+		// this condition can also hold (e.g. antipodal longitudes), but the
+		// geodesic there runs along the equator, not a meridian, and β
+		// carries no information at all about λ12 in that case.
+		cosα1 := math.Copysign(1, λ12)
+		if math.Abs(λ12) == math.Pi && β1+β2 < 0 {
+			// antimeridian pair: β2>=β1 always, but when both lie mostly in
+			// the southern hemisphere the shorter meridian arc goes via the
+			// south pole rather than the north
+			cosα1 = -1
+		}
+		sinα1 := 0.0
+		α2x, σ1x, σ2x, _, s12x, m12x, M12x, M21x, _ := g.hybrid(sinβ1, cosβ1, sinβ2, cosβ2, sinα1, cosα1)
+		α1 = math.Atan2(sinα1, cosα1)
+		α2, σ1, σ2, s12, m12, M12, M21 = α2x, σ1x, σ2x, s12x, m12x, M12x, M21x
+	default:
+		sinα1, cosα1 := g.inverseStart(sinβ1, cosβ1, sinβ2, cosβ2, λ12)
+		α1 = math.Atan2(sinα1, cosα1)
+		// the bracket tracks the sign of λ12: the λ12(α1) root always lies
+		// on the same side of zero as λ12 itself
+		α1a, α1b := 0.0, math.Pi
+		if λ12 < 0 {
+			α1a, α1b = -math.Pi, 0.0
+		}
+		for it := 0; it < maxit2; it++ {
+			sa1, ca1 := math.Sincos(α1)
+			α2x, σ1x, σ2x, λ12x, s12x, m12x, M12x, M21x, dn2x := g.hybrid(sinβ1, cosβ1, sinβ2, cosβ2, sa1, ca1)
+			v := λ12x - λ12
+			if it == maxit2-1 || math.Abs(v) < 1e-14 {
+				α2, σ1, σ2, s12, m12, M12, M21 = α2x, σ1x, σ2x, s12x, m12x, M12x, M21x
+				break
+			}
+			if v > 0 {
+				α1b = α1
+			} else {
+				α1a = α1
+			}
+			newα1 := math.NaN()
+			if it < maxit1 {
+				cosα2x := math.Cos(α2x)
+				deriv := m12x / (g.b * dn2x * cosα2x)
+				if deriv != 0 {
+					newα1 = α1 - v/deriv
+				}
+			}
+			if !(newα1 > α1a && newα1 < α1b) {
+				newα1 = (α1a + α1b) / 2
+			}
+			α1 = newα1
+		}
+	}
+	//
+	if flip {
+		α1 = math.Pi - α1
+		α2 = math.Pi - α2
+	}
+	if swap {
+		α1, α2 = α2, α1
+		M12, M21 = M21, M12
+	}
+	//
+	azi1 := normalizeDeg(α1 * (180 / math.Pi))
+	azi2 := normalizeDeg(α2 * (180 / math.Pi))
+	//
+	sol := Solution{Lat1: nnz(lat1), Lon1: nnz(lon1), Azi1: nnz(azi1), Lat2: nnz(lat2), Lon2: nnz(lon2), Azi2: nnz(azi2), S12: nnz(s12)}
+	if caps.has(CapArc) {
+		sol.Arc = nnz((σ2 - σ1) * (180 / math.Pi))
+	}
+	if caps.has(CapReducedLength) {
+		sol.ReducedLength = nnz(m12)
+	}
+	if caps.has(CapScale) {
+		sol.M12, sol.M21 = nnz(M12), nnz(M21)
+	}
+	return sol, nil
+}
+
+// Inverse -- solves the inverse problem: given the source point defined by `lat1`
+// and `lon1` and the target point defined by `lat2` and `lon2`, find the geodesic
+// length `s12` and the azimuths `azi1`, `azi2` at the two endpoints. `caps` selects
+// which of the optional `Solution` outputs (geodesic scales, reduced length, arc
+// length) are also computed; pass 0 to skip all of them.
+func (g Geodesic) Inverse(lat1, lon1, lat2, lon2 float64, caps Caps) Solution {
+	sol, err := g.InverseE(lat1, lon1, lat2, lon2, caps)
+	if err != nil {
+		panic("kgeo.Geodesic.Inverse: " + err.Error())
+	}
+	return sol
+}
+
+// inverseStart -- computes a starting guess for sin(α1), cos(α1), using the
+// spherical solution when the arc is not nearly antipodal, and otherwise the
+// real positive root of the astroid equation to derive α1 for near-antipodal
+// points on the spheroid.
+func (g Geodesic) inverseStart(sinβ1, cosβ1, sinβ2, cosβ2, λ12 float64) (sinα1, cosα1 float64) {
+	f := g.f
+	sinλ12, cosλ12 := math.Sincos(λ12)
+	sinα1 = cosβ2 * sinλ12
+	cosα1 = cosβ1*sinβ2 - sinβ1*cosβ2*cosλ12
+	nearlyAntipodal := cosλ12 < -1+10*f && math.Abs(sinβ1+sinβ2) < 10*f
+	if nearlyAntipodal {
+		δ := f * math.Pi * cosβ1 * cosβ1
+		if δ == 0 {
+			δ = f * math.Pi * 1e-16
+		}
+		lamScale := math.Pi - math.Abs(λ12)
+		if λ12 < 0 {
+			lamScale = -lamScale
+		}
+		x := lamScale / δ
+		y := (sinβ1 + sinβ2) / δ
+		k := solveAstroid(x, y)
+		sinα1, cosα1 = -x, y+k
+	}
+	norm := math.Hypot(sinα1, cosα1)
+	sinα1 /= norm
+	cosα1 /= norm
+	return
+}
+
+// solveAstroid -- finds the real positive root `k` of the astroid equation
+// k⁴+2k³−(x²+y²−1)k²−2y²k−y² = 0 via Cardano/Vieta, following GeographicLib's
+// formulation of the same problem.
+func solveAstroid(x, y float64) float64 {
+	p := x * x
+	q := y * y
+	r := (p + q - 3) / 6
+	if q == 0 && r <= 0 {
+		return 0
+	}
+	S := p * q / 4
+	r2 := r * r
+	r3 := r * r2
+	disc := S * (S + 2*r3)
+	u := r
+	if disc >= 0 {
+		T3 := S + r3
+		if T3 < 0 {
+			T3 = -math.Sqrt(disc)
+		} else {
+			T3 = math.Sqrt(disc)
+		}
+		if T3 < 0 {
+			T3 -= S
+		} else {
+			T3 += S
+		}
+		T := math.Cbrt(T3)
+		if T != 0 {
+			u += T + r2/T
+		}
+	} else {
+		ang := math.Atan2(math.Sqrt(-disc), -(S + r3))
+		u += 2 * r * math.Cos(ang/3)
+	}
+	v := math.Sqrt(u*u + q)
+	var uv float64
+	if u < 0 {
+		uv = q / (v - u)
+	} else {
+		uv = u + v
+	}
+	w := (uv - q) / (2 * v)
+	return uv / (math.Sqrt(uv+w*w) + w)
+}
+
+// normalizeDeg -- wraps an angle in degrees into (-180, +180].
+func normalizeDeg(deg float64) float64 {
+	deg = math.Mod(deg, 360)
+	if deg <= -180 {
+		deg += 360
+	}
+	if deg > 180 {
+		deg -= 360
+	}
+	return deg
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2021 Leonid Kneller. All rights reserved.
+// Licensed under the MIT license.
+// See the LICENSE file for full license information.
+
+package kgeo
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// roundTrip asserts that driving Direct with the azimuth/distance returned by
+// Inverse lands back on (lat2,lon2) within tol degrees.
+func roundTrip(t *testing.T, g Geodesic, lat1, lon1, lat2, lon2, tol float64) {
+	t.Helper()
+	sol := g.Inverse(lat1, lon1, lat2, lon2, 0)
+	rt := g.Direct(lat1, lon1, sol.Azi1, sol.S12, 0)
+	dlon := math.Abs(rt.Lon2 - lon2)
+	if dlon > 180 {
+		dlon = 360 - dlon
+	}
+	if math.Abs(rt.Lat2-lat2) > tol || dlon > tol {
+		t.Errorf("Inverse(%v,%v,%v,%v): azi1=%v s12=%v round-trips to (%v,%v), want (%v,%v)",
+			lat1, lon1, lat2, lon2, sol.Azi1, sol.S12, rt.Lat2, rt.Lon2, lat2, lon2)
+	}
+}
+
+// TestInverseWashingtonLondon is the textbook Washington DC -> London pair:
+// the largest |lat| belongs to the second point and both reduced colatitudes
+// are positive, so this exercises the swap and negate reductions together.
+func TestInverseWashingtonLondon(t *testing.T) {
+	g := NewGeodesic(6378137.0, 1.0/298.257223563)
+	sol := g.Inverse(38.9, -77.0, 51.5, -0.1, 0)
+	if math.Abs(sol.Azi1-49.389) > 1e-3 {
+		t.Errorf("Azi1 = %v, want approximately 49.389", sol.Azi1)
+	}
+	if math.Abs(sol.S12-5913129.2) > 1 {
+		t.Errorf("S12 = %v, want approximately 5913129.2", sol.S12)
+	}
+	roundTrip(t, g, 38.9, -77.0, 51.5, -0.1, 1e-9)
+}
+
+// TestInverseSwapNegateCombinations exercises all four combinations of the
+// swap (|β1|<|β2|) and negate (β1>0 after swap) reductions used internally
+// by Inverse, plus the meridional and equatorial shortcuts.
+func TestInverseSwapNegateCombinations(t *testing.T) {
+	g := NewGeodesic(6378137.0, 1.0/298.257223563)
+	cases := []struct {
+		name                   string
+		lat1, lon1, lat2, lon2 float64
+	}{
+		{"no-swap no-negate", -67.7589, -57.5399, -37.9871, -98.2360},
+		{"no-swap negate", 10.0, 0.0, 5.0, 10.0},
+		{"swap no-negate", -5.0, 0.0, -10.0, 10.0},
+		{"swap negate", 38.9, -77.0, 51.5, -0.1},
+		{"meridian", 10.0, 10.0, -10.0, 10.0},
+		{"near equator", 0.5, 0.0, -0.5, 10.0},
+		{"antimeridian crossing", 10.0, 179.0, 20.0, -179.0},
+		{"near antipodal", 30.0, 10.0, -29.7, -169.8},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			roundTrip(t, g, c.lat1, c.lon1, c.lat2, c.lon2, 1e-7)
+		})
+	}
+}
+
+// TestInverseMeridian checks the meridional shortcut (same longitude) lands on
+// the correct pole side: the shorter meridian arc always runs north since the
+// internal reduction guarantees the second point's reduced colatitude is no
+// smaller than the first's.
+func TestInverseMeridian(t *testing.T) {
+	g := NewGeodesic(6378137.0, 1.0/298.257223563)
+	sol := g.Inverse(0, 1, 1, 1, 0)
+	if math.Abs(sol.Azi1-0) > 1e-9 {
+		t.Errorf("Azi1 = %v, want 0", sol.Azi1)
+	}
+	if math.Abs(sol.S12-110574.389) > 1e-3 {
+		t.Errorf("S12 = %v, want approximately 110574.389", sol.S12)
+	}
+	roundTrip(t, g, 0, 1, 1, 1, 1e-9)
+}
+
+// TestInverseEquator checks the equatorial shortcut (both points on the
+// equator), where the usual β-based σ recovery is indeterminate and σ2 must
+// be solved for directly from λ12.
+func TestInverseEquator(t *testing.T) {
+	g := NewGeodesic(6378137.0, 1.0/298.257223563)
+	sol := g.Inverse(0, 0, 0, 1, 0)
+	if math.Abs(sol.Azi1-90) > 1e-9 {
+		t.Errorf("Azi1 = %v, want 90", sol.Azi1)
+	}
+	if math.Abs(sol.S12-111319.491) > 1e-3 {
+		t.Errorf("S12 = %v, want approximately 111319.491", sol.S12)
+	}
+	roundTrip(t, g, 0, 0, 0, 1, 1e-9)
+	// heading west must be the mirror image
+	solw := g.Inverse(0, 1, 0, 0, 0)
+	if math.Abs(solw.Azi1-(-90)) > 1e-9 {
+		t.Errorf("Azi1 = %v, want -90", solw.Azi1)
+	}
+	if math.Abs(solw.S12-sol.S12) > 1e-6 {
+		t.Errorf("S12 = %v, want %v", solw.S12, sol.S12)
+	}
+}
+
+// TestInverseEquatorAntipodal checks an equatorial pair with λ12 == ±180°,
+// which satisfies both the meridional shortcut's condition and the
+// equatorial shortcut's condition; the equatorial case must win, since on
+// the equator β carries no information about λ12 at all. s12 should be half
+// the equatorial circumference, in either direction.
+func TestInverseEquatorAntipodal(t *testing.T) {
+	g := NewGeodesic(6378137.0, 1.0/298.257223563)
+	halfEquator := math.Pi * g.A()
+	fwd := g.Inverse(0, 40, 0, -140, 0)
+	if math.Abs(fwd.S12-halfEquator) > 1e-3 {
+		t.Errorf("S12 = %v, want approximately %v", fwd.S12, halfEquator)
+	}
+	rev := g.Inverse(0, -140, 0, 40, 0)
+	if math.Abs(rev.S12-halfEquator) > 1e-3 {
+		t.Errorf("S12 = %v, want approximately %v", rev.S12, halfEquator)
+	}
+	if math.Abs(fwd.Azi1-(-90)) > 1e-9 {
+		t.Errorf("fwd.Azi1 = %v, want -90", fwd.Azi1)
+	}
+	if math.Abs(rev.Azi1-90) > 1e-9 {
+		t.Errorf("rev.Azi1 = %v, want 90", rev.Azi1)
+	}
+}
+
+// TestInverseRoundTripFuzz round-trips Inverse against Direct over a large
+// set of random point pairs, using a fixed seed so failures reproduce.
+func TestInverseRoundTripFuzz(t *testing.T) {
+	g := NewGeodesic(6378137.0, 1.0/298.257223563)
+	rng := rand.New(rand.NewSource(20210101))
+	for i := 0; i < 2000; i++ {
+		lat1 := -89.9 + rng.Float64()*179.8
+		lon1 := -180 + rng.Float64()*360
+		lat2 := -89.9 + rng.Float64()*179.8
+		lon2 := -180 + rng.Float64()*360
+		roundTrip(t, g, lat1, lon1, lat2, lon2, 1e-6)
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright (c) 2021 Leonid Kneller. All rights reserved.
+// Licensed under the MIT license.
+// See the LICENSE file for full license information.
+
+package kgeo
+
+import (
+	"math"
+)
+
+// lengths -- given the triangle-solving quantities already available at σ1 and
+// σ2 for one geodesic (the reduced colatitudes, their sines/cosines, the A1/C1
+// and A2/C2 series at the shared ε, and k² = ep2·cos²α0), returns the reduced
+// length m12 and the geodesic scales M12, M21.
+//
+// Reference: Karney, C.F.F. Algorithms for geodesics. J Geod 87, 43–55 (2013), §4.
+func lengths(b, k2, σ1, sinσ1, cosσ1, σ2, sinσ2, cosσ2 float64, A1 float64, C1 [8]float64, A2 float64, C2 [8]float64) (m12, M12, M21 float64) {
+	dn1 := math.Sqrt(1 + k2*cosσ1*cosσ1)
+	dn2 := math.Sqrt(1 + k2*cosσ2*cosσ2)
+	B1 := sumSin(σ2, C1) - sumSin(σ1, C1)
+	B2 := sumSin(σ2, C2) - sumSin(σ1, C2)
+	m0 := A1 - A2
+	J12 := m0*(σ2-σ1) + (A1*B1 - A2*B2)
+	//
+	m12 = b * (dn2*cosσ1*sinσ2 - dn1*sinσ1*cosσ2 - cosσ1*cosσ2*J12)
+	//
+	cosσ12 := cosσ1*cosσ2 + sinσ1*sinσ2
+	t := k2 * (sinσ2 - sinσ1) * (sinσ2 + sinσ1) / (dn1 + dn2)
+	M12 = cosσ12 + (t*sinσ2/dn1-cosσ2*J12)*sinσ1/dn1
+	M21 = cosσ12 - (t*sinσ1/dn2-cosσ1*J12)*sinσ2/dn2
+	return
+}
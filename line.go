@@ -0,0 +1,184 @@
+// Copyright (c) 2021 Leonid Kneller. All rights reserved.
+// Licensed under the MIT license.
+// See the LICENSE file for full license information.
+
+package kgeo
+
+import (
+	"math"
+)
+
+// GeodesicLine -- represents a single geodesic anchored at a starting point
+// and azimuth, with the series that depend on the starting point cached so
+// that repeated calls to `Position`/`ArcPosition` along the same line are
+// cheap. Use `Geodesic.Line` to construct one.
+type GeodesicLine struct {
+	b, f             float64
+	caps             Caps
+	lat1, lon1, azi1 float64
+	sinα0, cosα0     float64
+	k2               float64
+	σ1, λ1, s1       float64
+	A1               float64
+	C1, C1p          [8]float64
+	A2               float64
+	C2               [8]float64
+	A3               float64
+	C3               [8]float64
+}
+
+// LineE -- like `Line`, but reports an invalid argument or a pole-degenerate
+// `lat1` by returning an error (`ErrLatOutOfRange`, `ErrLonOutOfRange`,
+// `ErrAziOutOfRange`, `ErrPoleDegenerate`) instead of panicking or silently
+// clamping.
+func (g Geodesic) LineE(lat1, lon1, azi1 float64, caps Caps) (GeodesicLine, error) {
+	if !(-90 <= lat1 && lat1 <= +90) {
+		return GeodesicLine{}, ErrLatOutOfRange
+	}
+	if !(-180 <= lon1 && lon1 <= +180) {
+		return GeodesicLine{}, ErrLonOutOfRange
+	}
+	if !(-180 <= azi1 && azi1 <= +180) {
+		return GeodesicLine{}, ErrAziOutOfRange
+	}
+	if math.Abs(lat1) == 90 {
+		// the azimuth at a pole is not well defined; let the caller resolve it
+		return GeodesicLine{}, ErrPoleDegenerate
+	}
+	//
+	b := g.b
+	f := g.f
+	ep2 := g.ep2
+	//
+	φ1 := lat1 * (math.Pi / 180)
+	sinφ1, cosφ1 := math.Sincos(φ1)
+	α1 := azi1 * (math.Pi / 180)
+	sinα1, cosα1 := math.Sincos(α1)
+	β1 := math.Atan2((1-f)*sinφ1, cosφ1)
+	sinβ1, cosβ1 := math.Sincos(β1)
+	α0 := math.Atan2(sinα1*cosβ1, math.Hypot(cosα1, sinα1*sinβ1))
+	sinα0, cosα0 := math.Sincos(α0)
+	σ1 := math.Atan2(sinβ1, cosα1*cosβ1)
+	sinσ1, cosσ1 := math.Sincos(σ1)
+	ω1 := math.Atan2(sinα0*sinσ1, cosσ1)
+	//
+	k2 := ep2 * cosα0 * cosα0
+	tt := math.Sqrt(1 + k2)
+	ε := (tt - 1) / (tt + 1)
+	A1 := seriesA1(ε)
+	C1 := seriesC1(ε)
+	C1p := seriesC1p(ε)
+	I1σ1 := A1 * (σ1 + sumSin(σ1, C1))
+	s1 := b * I1σ1
+	A3 := g.cA3.seriesA3(ε)
+	C3 := g.cC3.seriesC3(ε)
+	I3σ1 := A3 * (σ1 + sumSin(σ1, C3))
+	λ1 := ω1 - f*sinα0*I3σ1
+	A2 := seriesA2(ε)
+	C2 := seriesC2(ε)
+	//
+	return GeodesicLine{
+		b: b, f: f, caps: caps, lat1: nnz(lat1), lon1: nnz(lon1), azi1: nnz(azi1),
+		sinα0: sinα0, cosα0: cosα0, k2: k2, σ1: σ1, λ1: λ1, s1: s1,
+		A1: A1, C1: C1, C1p: C1p, A2: A2, C2: C2, A3: A3, C3: C3,
+	}, nil
+}
+
+// Line -- returns a `GeodesicLine` for the geodesic starting at `lat1`, `lon1`
+// with azimuth `azi1`, on the spheroid of `g`. `caps` selects which of the
+// optional `Solution` outputs (geodesic scales, reduced length, arc length)
+// `Position` and `ArcPosition` also compute; pass 0 to skip all of them. Use
+// `Position` or `ArcPosition` on the result to find points along the geodesic
+// without recomputing the series that only depend on the starting point and
+// azimuth.
+func (g Geodesic) Line(lat1, lon1, azi1 float64, caps Caps) GeodesicLine {
+	l, err := g.LineE(lat1, lon1, azi1, caps)
+	if err != nil {
+		panic("kgeo.Geodesic.Line: " + err.Error())
+	}
+	return l
+}
+
+// PositionE -- like `Position`, but reports an invalid argument
+// (`ErrDistanceOutOfRange`) instead of panicking.
+func (l GeodesicLine) PositionE(s12 float64) (Solution, error) {
+	if !(0 <= s12 && s12 <= 1e11) {
+		return Solution{}, ErrDistanceOutOfRange
+	}
+	s2 := l.s1 + s12
+	τ2 := s2 / (l.b * l.A1)
+	σ2 := τ2 + sumSin(τ2, l.C1p)
+	return l.position(σ2), nil
+}
+
+// Position -- returns the point at the geodesic length `s12` from the start
+// of `l`, and the azimuth there.
+func (l GeodesicLine) Position(s12 float64) Solution {
+	sol, err := l.PositionE(s12)
+	if err != nil {
+		panic("kgeo.GeodesicLine.Position: " + err.Error())
+	}
+	return sol
+}
+
+// ArcPositionE -- the error-returning counterpart of `ArcPosition`; see
+// `PositionE`. `a12Degrees` is unconstrained, so this never returns an error.
+func (l GeodesicLine) ArcPositionE(a12Degrees float64) (Solution, error) {
+	σ2 := l.σ1 + a12Degrees*(math.Pi/180)
+	return l.position(σ2), nil
+}
+
+// ArcPosition -- returns the point at the arc length `a12Degrees` (in degrees,
+// on the auxiliary sphere) from the start of `l`, and the azimuth there. This
+// skips the τ→σ inversion that `Position` performs, so it is the cheaper and
+// numerically preferred way to sample equally-spaced points along a geodesic.
+func (l GeodesicLine) ArcPosition(a12Degrees float64) Solution {
+	sol, _ := l.ArcPositionE(a12Degrees)
+	return sol
+}
+
+// position -- common tail of `Position` and `ArcPosition`, given σ2.
+func (l GeodesicLine) position(σ2 float64) Solution {
+	f := l.f
+	sinα0, cosα0 := l.sinα0, l.cosα0
+	sinσ2, cosσ2 := math.Sincos(σ2)
+	α2 := math.Atan2(sinα0, cosα0*cosσ2)
+	β2 := math.Atan2(cosα0*sinσ2, math.Hypot(cosα0*cosσ2, sinα0))
+	sinβ2, cosβ2 := math.Sincos(β2)
+	ω2 := math.Atan2(sinα0*sinσ2, cosσ2)
+	φ2 := math.Atan2(sinβ2, (1-f)*cosβ2)
+	//
+	I3σ2 := l.A3 * (σ2 + sumSin(σ2, l.C3))
+	λ2 := ω2 - f*sinα0*I3σ2
+	λ12 := λ2 - l.λ1
+	//
+	I1σ2 := l.A1 * (σ2 + sumSin(σ2, l.C1))
+	s2 := l.b * I1σ2
+	s12 := s2 - l.s1
+	//
+	lat2 := φ2 * (180 / math.Pi)
+	lon2 := l.lon1 + λ12*(180/math.Pi)
+	if lon2 < -180 {
+		lon2 += 360
+	}
+	if lon2 > +180 {
+		lon2 -= 360
+	}
+	azi2 := α2 * (180 / math.Pi)
+	//
+	sol := Solution{Lat1: l.lat1, Lon1: l.lon1, Azi1: l.azi1, Lat2: nnz(lat2), Lon2: nnz(lon2), Azi2: nnz(azi2), S12: nnz(s12)}
+	if l.caps.has(CapArc) {
+		sol.Arc = nnz((σ2 - l.σ1) * (180 / math.Pi))
+	}
+	if l.caps.has(CapScale) || l.caps.has(CapReducedLength) {
+		sinσ1, cosσ1 := math.Sincos(l.σ1)
+		m12, M12, M21 := lengths(l.b, l.k2, l.σ1, sinσ1, cosσ1, σ2, sinσ2, cosσ2, l.A1, l.C1, l.A2, l.C2)
+		if l.caps.has(CapReducedLength) {
+			sol.ReducedLength = nnz(m12)
+		}
+		if l.caps.has(CapScale) {
+			sol.M12, sol.M21 = nnz(M12), nnz(M21)
+		}
+	}
+	return sol
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2021 Leonid Kneller. All rights reserved.
+// Licensed under the MIT license.
+// See the LICENSE file for full license information.
+
+package kgeo
+
+import (
+	"math"
+	"testing"
+)
+
+// TestGeodesicLinePositionE checks that PositionE reports an out-of-range
+// s12 instead of panicking, and that Position/ArcPosition agree with Direct
+// and ArcDirect for an in-range argument.
+func TestGeodesicLinePositionE(t *testing.T) {
+	g := NewGeodesic(6378137.0, 1.0/298.257223563)
+	l := g.Line(38.9, -77.0, 49.389, 0)
+	//
+	if _, err := l.PositionE(-1); err != ErrDistanceOutOfRange {
+		t.Errorf("PositionE(-1) error = %v, want ErrDistanceOutOfRange", err)
+	}
+	//
+	pos := l.Position(1000000)
+	dir := g.Direct(38.9, -77.0, 49.389, 1000000, 0)
+	if math.Abs(pos.Lat2-dir.Lat2) > 1e-9 || math.Abs(pos.Lon2-dir.Lon2) > 1e-9 {
+		t.Errorf("Position = (%v,%v), want (%v,%v)", pos.Lat2, pos.Lon2, dir.Lat2, dir.Lon2)
+	}
+	//
+	arc := l.ArcPosition(5)
+	arcDir := g.ArcDirect(38.9, -77.0, 49.389, 5, 0)
+	if math.Abs(arc.Lat2-arcDir.Lat2) > 1e-9 || math.Abs(arc.Lon2-arcDir.Lon2) > 1e-9 {
+		t.Errorf("ArcPosition = (%v,%v), want (%v,%v)", arc.Lat2, arc.Lon2, arcDir.Lat2, arcDir.Lon2)
+	}
+}
+
+// TestGeodesicLinePositionPanics checks that Position panics on an
+// out-of-range s12, matching Direct's panic convention.
+func TestGeodesicLinePositionPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Position(-1) did not panic")
+		}
+	}()
+	g := NewGeodesic(6378137.0, 1.0/298.257223563)
+	l := g.Line(0, 0, 0, 0)
+	l.Position(-1)
+}
